@@ -0,0 +1,76 @@
+package splot
+
+// XLabel sets the x-axis label, emitted as `set xlabel`.
+func (p *Plot) XLabel(s string) *Plot {
+	p.xlabel = s
+	return p
+}
+
+// YLabel sets the y-axis label, emitted as `set ylabel`.
+func (p *Plot) YLabel(s string) *Plot {
+	p.ylabel = s
+	return p
+}
+
+// ZLabel sets the z-axis label, emitted as `set zlabel`.
+func (p *Plot) ZLabel(s string) *Plot {
+	p.zlabel = s
+	return p
+}
+
+// XRange sets the x-axis range, emitted as `set xrange`.
+func (p *Plot) XRange(min, max float32) *Plot {
+	p.xrange = [2]float32{min, max}
+	p.hasXRange = true
+	return p
+}
+
+// YRange sets the y-axis range, emitted as `set yrange`.
+func (p *Plot) YRange(min, max float32) *Plot {
+	p.yrange = [2]float32{min, max}
+	p.hasYRange = true
+	return p
+}
+
+// ZRange sets the z-axis range, emitted as `set zrange`.
+func (p *Plot) ZRange(min, max float32) *Plot {
+	p.zrange = [2]float32{min, max}
+	p.hasZRange = true
+	return p
+}
+
+// Grid turns the background grid on or off, emitted as `set grid`.
+func (p *Plot) Grid(on bool) *Plot {
+	p.grid = on
+	return p
+}
+
+// Equal controls whether the axes keep an equal aspect ratio, emitted as
+// `set view equal xyz`. Plots default to true unless Equal(false) is called.
+func (p *Plot) Equal(on bool) *Plot {
+	p.equal = &on
+	return p
+}
+
+// LogScale enables a logarithmic scale for the given axes, e.g. "x" or "xz",
+// emitted as `set logscale`. Calling it again appends to the axis set.
+func (p *Plot) LogScale(axes string) *Plot {
+	p.logscale += axes
+	return p
+}
+
+// View sets the 3D view angles, emitted as `set view elev,azim` (gnuplot
+// takes the vertical rotation before the azimuthal one).
+func (p *Plot) View(azim, elev float32) *Plot {
+	p.azim, p.elev = azim, elev
+	p.hasView = true
+	return p
+}
+
+// Legend shows the plot key at pos (e.g. "top right"), emitted as `set key
+// pos`. Without a call to Legend, the key is hidden via `unset key`.
+func (p *Plot) Legend(pos string) *Plot {
+	p.legend = pos
+	p.legendSet = true
+	return p
+}
@@ -0,0 +1,105 @@
+package splot
+
+import "math"
+
+// maxBezierDepth bounds the recursive subdivision so a degenerate curve
+// (e.g. coincident control points) can't recurse forever.
+const maxBezierDepth = 16
+
+// defaultBezierDivisor sets the default chord tolerance as a fraction of the
+// control-polygon extent when BezierTolerance has not been called.
+const defaultBezierDivisor = 500
+
+// BezierTolerance sets the chord tolerance used when flattening
+// CubicBezier/QuadraticBezier curves into line segments, in the same world
+// units as the plot coordinates. tol<=0 restores the default, which is about
+// 1/500 of the curve's control-polygon extent.
+func (p *Plot) BezierTolerance(tol float32) *Plot {
+	p.bezierTol = tol
+	return p
+}
+
+func midpoint(a, b Vec3) Vec3 {
+	return Vec3{(a[0] + b[0]) / 2, (a[1] + b[1]) / 2, (a[2] + b[2]) / 2}
+}
+
+func sub3(a, b Vec3) Vec3 {
+	return Vec3{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func norm3(v Vec3) float32 {
+	return float32(math.Sqrt(float64(Dot(v, v))))
+}
+
+// cubicFlatness estimates how far a cubic Bézier deviates from a straight
+// line, using the Euclidean norm of the two second-difference vectors.
+func cubicFlatness(p0, c0, c1, p1 Vec3) float32 {
+	d1 := Vec3{p0[0] - 2*c0[0] + c1[0], p0[1] - 2*c0[1] + c1[1], p0[2] - 2*c0[2] + c1[2]}
+	d2 := Vec3{c0[0] - 2*c1[0] + p1[0], c0[1] - 2*c1[1] + p1[1], c0[2] - 2*c1[2] + p1[2]}
+	n1, n2 := norm3(d1), norm3(d2)
+	if n1 > n2 {
+		return n1
+	}
+	return n2
+}
+
+func bezierExtent(pts ...Vec3) float32 {
+	lo, hi := pts[0], pts[0]
+	for _, q := range pts[1:] {
+		for i := 0; i < 3; i++ {
+			if q[i] < lo[i] {
+				lo[i] = q[i]
+			}
+			if q[i] > hi[i] {
+				hi[i] = q[i]
+			}
+		}
+	}
+	return norm3(sub3(hi, lo))
+}
+
+func (p *Plot) bezierTolerance(pts ...Vec3) float32 {
+	if p.bezierTol > 0 {
+		return p.bezierTol
+	}
+	extent := bezierExtent(pts...)
+	if extent <= 0 {
+		return 1e-4
+	}
+	return extent / defaultBezierDivisor
+}
+
+func (p *Plot) flattenCubic(p0, c0, c1, p1 Vec3, tol float32, depth int) {
+	if depth >= maxBezierDepth || cubicFlatness(p0, c0, c1, p1) <= tol {
+		p.LineTo(p1)
+		return
+	}
+	m0 := midpoint(p0, c0)
+	m1 := midpoint(c0, c1)
+	m2 := midpoint(c1, p1)
+	m3 := midpoint(m0, m1)
+	m4 := midpoint(m1, m2)
+	m5 := midpoint(m3, m4)
+	p.flattenCubic(p0, m0, m3, m5, tol, depth+1)
+	p.flattenCubic(m5, m4, m2, p1, tol, depth+1)
+}
+
+// CubicBezier tessellates a cubic Bézier curve from p0 to p1 (control points
+// c0, c1) into a styled polyline, using recursive adaptive subdivision so
+// that each flattened segment deviates from the true curve by no more than
+// BezierTolerance (default ~1/500 of the control-polygon extent).
+func (p *Plot) CubicBezier(p0, c0, c1, p1 Vec3) *Plot {
+	tol := p.bezierTolerance(p0, c0, c1, p1)
+	p.MoveTo(p0)
+	p.flattenCubic(p0, c0, c1, p1, tol, 0)
+	return p
+}
+
+// QuadraticBezier tessellates a quadratic Bézier curve from p0 to p1
+// (control point c) by degree-elevating it to a cubic and flattening that,
+// see CubicBezier.
+func (p *Plot) QuadraticBezier(p0, c, p1 Vec3) *Plot {
+	c0 := Vec3{p0[0] + 2.0/3*(c[0]-p0[0]), p0[1] + 2.0/3*(c[1]-p0[1]), p0[2] + 2.0/3*(c[2]-p0[2])}
+	c1 := Vec3{p1[0] + 2.0/3*(c[0]-p1[0]), p1[1] + 2.0/3*(c[1]-p1[1]), p1[2] + 2.0/3*(c[2]-p1[2])}
+	return p.CubicBezier(p0, c0, c1, p1)
+}
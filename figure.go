@@ -0,0 +1,84 @@
+package splot
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+type subplotEntry struct {
+	idx  int
+	plot *Plot
+}
+
+// Figure composes multiple Plots into a gnuplot multiplot grid.
+type Figure struct {
+	rows, cols int
+	subplots   []subplotEntry
+	title      string
+}
+
+// Subplot places p at cell idx (0-based, row-major) of a rows x cols grid.
+func (f *Figure) Subplot(rows, cols, idx int, p *Plot) *Figure {
+	f.rows, f.cols = rows, cols
+	f.subplots = append(f.subplots, subplotEntry{idx, p})
+	return f
+}
+
+// Title sets a title shown above the whole grid.
+func (f *Figure) Title(s string) *Figure {
+	f.title = s
+	return f
+}
+
+// Write into a gnuplot file.
+func (f *Figure) Write(filename string) (err error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		err1 := file.Close()
+		if err == nil {
+			err = err1
+		}
+	}()
+
+	err = f.Encode(file)
+	return
+}
+
+// Encode gnuplot multiplot format into writer w. Each subplot gets an
+// explicit `set origin`/`set size` for its cell rather than relying on
+// `set multiplot layout` to advance through the grid, since layout fills
+// cells in emission order and would misplace a subplot whenever its idx
+// leaves a gap (e.g. skipping a cell to leave it blank).
+func (f *Figure) Encode(w io.Writer) (err error) {
+	subplots := append([]subplotEntry(nil), f.subplots...)
+	sort.Slice(subplots, func(i, j int) bool {
+		return subplots[i].idx < subplots[j].idx
+	})
+
+	fmt.Fprintf(w, "set multiplot")
+	if f.title != "" {
+		fmt.Fprintf(w, " title %q", f.title)
+	}
+	fmt.Fprintln(w)
+
+	cellW, cellH := 1/float64(f.cols), 1/float64(f.rows)
+	for _, s := range subplots {
+		row, col := s.idx/f.cols, s.idx%f.cols
+		x, y := float64(col)*cellW, 1-float64(row+1)*cellH
+		fmt.Fprintf(w, "set origin %g,%g\n", x, y)
+		fmt.Fprintf(w, "set size %g,%g\n", cellW, cellH)
+		if err = s.plot.encode(w, false); err != nil {
+			return
+		}
+	}
+
+	fmt.Fprintln(w, `unset multiplot`)
+	fmt.Fprintln(w, "pause mouse keypress")
+	return
+}
@@ -0,0 +1,44 @@
+package splot
+
+// newPolygon appends a filled-face primitive, inheriting the current style
+// (e.g. color). It clears the break flag like new() does, and seeds p0/p1
+// from the current position; Polygon then sets p1 to the face's last vertex
+// once verts is known, so CurPos/MoveTo/LineTo keep resuming from the true
+// last position after a polygon call.
+func (p *Plot) newPolygon() *primitive {
+	last := p.last()
+	p.start = false
+	cur := primitive{style: last.style}
+	cur.p0, cur.p1 = last.p1, last.p1
+	cur.isPoint = false
+	cur.isPolygon = true
+	p.prims = append(p.prims, cur)
+	return &p.prims[len(p.prims)-1]
+}
+
+// Polygon adds a filled face spanning verts, emitted via gnuplot's `with
+// polygons`.
+func (p *Plot) Polygon(verts []Vec3) *Plot {
+	cur := p.newPolygon()
+	cur.verts = append([]Vec3(nil), verts...)
+	if len(verts) > 0 {
+		cur.p1 = verts[len(verts)-1]
+	}
+	return p
+}
+
+// Triangle is a convenience for Polygon with exactly three vertices.
+func (p *Plot) Triangle(a, b, c Vec3) *Plot {
+	return p.Polygon([]Vec3{a, b, c})
+}
+
+// Surface adds one quad face per cell of a parametric mesh grid[row][col].
+func (p *Plot) Surface(grid [][]Vec3) *Plot {
+	for i := 0; i+1 < len(grid); i++ {
+		row, next := grid[i], grid[i+1]
+		for j := 0; j+1 < len(row) && j+1 < len(next); j++ {
+			p.Polygon([]Vec3{row[j], row[j+1], next[j+1], next[j]})
+		}
+	}
+	return p
+}
@@ -71,19 +71,22 @@ func mat3MulVec3(m mat3, a [3]float32) (b [3]float32) {
 
 type style struct {
 	color     string
-	width     int    // 1, 2, 3 ...
-	lineAttr  string // "filled head" "nohead" etc.
-	pointAttr string //
-	isPoint   bool   // 考虑到有的线段长度为零
+	width     int       // 1, 2, 3 ...
+	lineAttr  string    // "filled head" "nohead" etc.
+	pointAttr string    //
+	isPoint   bool      // 考虑到有的线段长度为零
+	isPolygon bool      // filled face, drawn from primitive.verts
+	dash      []float32 // on/off pattern in world units, nil means solid
 
 	key string
 	cv  int
 }
 
 type primitive struct {
-	p0   Vec3   // 端点坐标
-	p1   Vec3   // 矢量方向
-	text string // 标注
+	p0    Vec3   // 端点坐标
+	p1    Vec3   // 矢量方向
+	verts []Vec3 // face vertices, used when isPolygon
+	text  string // 标注
 	style
 }
 
@@ -92,12 +95,18 @@ func (p *style) prepareStyleKey() {
 		p.color = "black"
 	}
 	var c, a string
-	if p.isPoint {
+	var kind byte
+	switch {
+	case p.isPolygon:
+		kind = 'G'
+	case p.isPoint:
+		kind = 'P'
 		if p.width <= 0 {
 			p.width = 3
 		}
 		a = p.pointAttr
-	} else {
+	default:
+		kind = 'L'
 		if p.width <= 0 {
 			p.width = 1
 		}
@@ -108,19 +117,21 @@ func (p *style) prepareStyleKey() {
 	}
 	c = p.color
 
-	// isPoint  width  color | attr
-	n := 1 + 2 + len(c) + 1 + len(a)
-	buf := make([]byte, n)
-	if p.isPoint {
-		buf[0] = 'P'
-	} else {
-		buf[0] = 'L'
+	var dashStr string
+	if len(p.dash) > 0 {
+		dashStr = "|d" + dashTypeSpec(p.dash)
 	}
+
+	// kind  width  color | attr | dash
+	n := 1 + 2 + len(c) + 1 + len(a) + len(dashStr)
+	buf := make([]byte, n)
+	buf[0] = kind
 	buf[1] = byte('0' + p.width/10%10)
 	buf[2] = byte('0' + p.width%10)
 	copy(buf[3:3+len(c)], c)
 	buf[3+len(c)] = '|'
-	copy(buf[4+len(c):], a)
+	copy(buf[4+len(c):4+len(c)+len(a)], a)
+	copy(buf[4+len(c)+len(a):], dashStr)
 	p.key = string(buf)
 }
 
@@ -132,7 +143,16 @@ func (p *primitive) dir() Vec3 {
 }
 
 func (p *primitive) smartLabelPos() (pt Vec3) {
-	if p.isPoint {
+	if p.isPolygon {
+		for _, v := range p.verts {
+			pt[0] += v[0]
+			pt[1] += v[1]
+			pt[2] += v[2]
+		}
+		if n := float32(len(p.verts)); n > 0 {
+			pt = Vec3{pt[0] / n, pt[1] / n, pt[2] / n}
+		}
+	} else if p.isPoint {
 		pt = p.p0
 	} else {
 		f0, _ := math.Frexp(float64(p.p1[0]))
@@ -154,6 +174,19 @@ type Plot struct {
 	prims []primitive
 	dummy primitive
 	start bool
+
+	bezierTol float32
+
+	xlabel, ylabel, zlabel          string
+	xrange, yrange, zrange          [2]float32
+	hasXRange, hasYRange, hasZRange bool
+	grid                            bool
+	equal                           *bool // nil means the default (true)
+	logscale                        string
+	hasView                         bool
+	azim, elev                      float32
+	legend                          string
+	legendSet                       bool
 }
 
 func (p *Plot) last() *primitive {
@@ -180,6 +213,7 @@ func (p *Plot) new() *primitive {
 	cur := &p.prims[len(p.prims)-1]
 	cur.text = ""
 	cur.p0, cur.p1, cur.isPoint = last.p1, Vec3{}, true
+	cur.isPolygon, cur.verts = false, nil
 	return cur
 }
 
@@ -238,14 +272,11 @@ func (p *Plot) Circle(center, normal Vec3, radius float32) *Plot {
 	return p.Arc(center, normal, radius, 0, math.Pi*2)
 }
 
-func (p *Plot) Arc(center, normal Vec3, radius, angle0, angle1 float32) *Plot {
-	a0, a1 := angle0, angle1
-	if a0 == a1 {
-		return p
-	}
-
+// planeBasis picks an orthonormal (tangent, bitangent) pair spanning the
+// plane perpendicular to normal, used to map local 2D coordinates into the
+// plot's 3D space.
+func planeBasis(normal Vec3) (tangent, bitangent Vec3) {
 	normal = Normalize(normal)
-	var tangent, bitangent Vec3
 	tangent = Vec3{1, 0, 0}
 	if Abs(Dot(normal, tangent)) < 0.9 {
 		bitangent = Normalize(Cross(normal, tangent))
@@ -255,6 +286,17 @@ func (p *Plot) Arc(center, normal Vec3, radius, angle0, angle1 float32) *Plot {
 		tangent = Normalize(Cross(bitangent, normal))
 		bitangent = Normalize(Cross(normal, tangent))
 	}
+	return
+}
+
+func (p *Plot) Arc(center, normal Vec3, radius, angle0, angle1 float32) *Plot {
+	a0, a1 := angle0, angle1
+	if a0 == a1 {
+		return p
+	}
+
+	normal = Normalize(normal)
+	tangent, bitangent := planeBasis(normal)
 	rot := mat3{tangent, bitangent, normal}
 
 	// estimate angle step delta
@@ -376,13 +418,53 @@ func (p *Plot) Write(filename string) (err error) {
 
 // Encode gunplot format into writer w
 func (p *Plot) Encode(w io.Writer) (err error) {
+	return p.encode(w, true)
+}
+
+// encode writes the gnuplot script for p. pause controls whether the
+// trailing `pause mouse keypress` is emitted; Figure suppresses it on each
+// subplot and emits one of its own after the whole grid.
+func (p *Plot) encode(w io.Writer, pause bool) (err error) {
 
 	// write common properties
 	if p.title != "" {
 		fmt.Fprintf(w, "set title %q\n", p.title)
 	}
-	fmt.Fprintln(w, `set view equal xyz`)
-	fmt.Fprintln(w, `unset key`)
+	if p.xlabel != "" {
+		fmt.Fprintf(w, "set xlabel %q\n", p.xlabel)
+	}
+	if p.ylabel != "" {
+		fmt.Fprintf(w, "set ylabel %q\n", p.ylabel)
+	}
+	if p.zlabel != "" {
+		fmt.Fprintf(w, "set zlabel %q\n", p.zlabel)
+	}
+	if p.hasXRange {
+		fmt.Fprintf(w, "set xrange [%g:%g]\n", p.xrange[0], p.xrange[1])
+	}
+	if p.hasYRange {
+		fmt.Fprintf(w, "set yrange [%g:%g]\n", p.yrange[0], p.yrange[1])
+	}
+	if p.hasZRange {
+		fmt.Fprintf(w, "set zrange [%g:%g]\n", p.zrange[0], p.zrange[1])
+	}
+	if p.grid {
+		fmt.Fprintln(w, `set grid`)
+	}
+	if p.logscale != "" {
+		fmt.Fprintf(w, "set logscale %s\n", p.logscale)
+	}
+	if p.equal == nil || *p.equal {
+		fmt.Fprintln(w, `set view equal xyz`)
+	}
+	if p.hasView {
+		fmt.Fprintf(w, "set view %g,%g\n", p.elev, p.azim)
+	}
+	if p.legendSet {
+		fmt.Fprintln(w, strings.TrimSpace("set key "+p.legend))
+	} else {
+		fmt.Fprintln(w, `unset key`)
+	}
 	if len(p.prims) == 0 {
 		return
 	}
@@ -461,8 +543,18 @@ func (p *Plot) Encode(w io.Writer) (err error) {
 	// there are actually multiple plots:
 	//   plot_1, label_1, plot_2, label_2, plot_3, label_3 ...
 
+	var polygonPrims []*primitive
+	for i := range p.prims {
+		if p.prims[i].isPolygon {
+			polygonPrims = append(polygonPrims, &p.prims[i])
+		}
+	}
+
 	first := true
 	for _, style := range styleList {
+		if style.isPolygon {
+			continue // drawn together as a single "with polygons" block below
+		}
 		if first {
 			first = false
 			fmt.Fprint(w, `splot "-" `)
@@ -475,15 +567,29 @@ func (p *Plot) Encode(w io.Writer) (err error) {
 		} else {
 			// draw vector/line
 			fmt.Fprintf(w, ` using 1:2:3:4:5:6:7 with vectors %s linewidth %d palette`, style.lineAttr, style.width)
-
+			if len(style.dash) > 0 {
+				fmt.Fprintf(w, ` dashtype (%s)`, dashTypeSpec(style.dash))
+			}
 		}
 	}
+	if len(polygonPrims) > 0 {
+		if first {
+			first = false
+			fmt.Fprint(w, `splot "-" `)
+		} else {
+			fmt.Fprint(w, " \\\n  , \"\" ")
+		}
+		fmt.Fprint(w, `using 1:2:3:4 with polygons fillcolor palette fillstyle transparent solid 0.5`)
+	}
 	// draw label text
 	fmt.Fprintf(w, ` , "" using 1:2:3:4:5 with labels left textcolor palette offset char 1,char 1`)
 
 	fmt.Fprintln(w) // separate between gnuplot command an data tables
 
 	for _, style := range styleList {
+		if style.isPolygon {
+			continue
+		}
 		if style.isPoint {
 			// point data
 			p.foreachPrim(style, func(v *primitive) {
@@ -495,15 +601,25 @@ func (p *Plot) Encode(w io.Writer) (err error) {
 
 		} else {
 			// vector/line data
+			st := style
 			p.foreachPrim(style, func(v *primitive) {
-				d := v.dir()
-				fmt.Fprint(w, v.p0[0], sep, v.p0[1], sep, v.p0[2], sep, d[0], sep, d[1], sep, d[2], sep, style.cv)
-				fmt.Fprintln(w)
+				writeVectorData(w, v, &st, sep)
 			})
 
 			fmt.Fprintln(w, "e") // separate between data tables
 		}
 	}
+	if len(polygonPrims) > 0 {
+		// polygon data: one blank-line-separated vertex loop per face
+		for _, v := range polygonPrims {
+			for _, vert := range v.verts {
+				fmt.Fprint(w, vert[0], sep, vert[1], sep, vert[2], sep, v.cv)
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, "e") // separate between data tables
+	}
 	// point label data
 	for _, v := range p.prims {
 		if v.text != "" {
@@ -515,7 +631,9 @@ func (p *Plot) Encode(w io.Writer) (err error) {
 	fmt.Fprintln(w, "e")
 
 	// allow ineractive op
-	fmt.Fprintln(w, "pause mouse keypress")
+	if pause {
+		fmt.Fprintln(w, "pause mouse keypress")
+	}
 
 	return
 }
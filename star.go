@@ -0,0 +1,68 @@
+package splot
+
+import "math"
+
+// Star renders a radar/star chart of values in the XY plane: one radial
+// axis per value at equal angles (with arrowheads and, where given, a
+// label), concentric guide circles at 0.25/0.5/0.75/1.0 of the axis length,
+// and a closed polygon through the data points, each placed at
+// ρ = values[i] / max along its axis. Call Star again with a different
+// current color (e.g. via StdColor) to overlay another dataset's polygon on
+// the same axes.
+func (p *Plot) Star(values []float32, labels []string) *Plot {
+	n := len(values)
+	if n == 0 {
+		return p
+	}
+	dataColor := p.last().color
+
+	maxV := values[0]
+	for _, v := range values[1:] {
+		if v > maxV {
+			maxV = v
+		}
+	}
+	if maxV <= 0 {
+		maxV = 1
+	}
+
+	origin := Vec3{}
+	da := float32(2 * math.Pi / float64(n))
+
+	p.Break().Color("gray").NoHead().Width(1)
+	for _, frac := range []float32{0.25, 0.5, 0.75, 1.0} {
+		p.Circle(origin, Vec3{0, 0, 1}, frac)
+	}
+
+	p.Break().Color("gray").Width(1).FilledHead()
+	for i := 0; i < n; i++ {
+		s, c := sincosf(float32(i) * da)
+		p.Vector(origin, Vec3{c, s, 0})
+		if i < len(labels) {
+			p.Text(labels[i])
+		}
+	}
+
+	p.Break().Color(dataColor).NoHead().Width(2)
+	first := Vec3{values[0] / maxV, 0, 0}
+	for i := 0; i < n; i++ {
+		s, c := sincosf(float32(i) * da)
+		rho := values[i] / maxV
+		pt := Vec3{rho * c, rho * s, 0}
+		if i == 0 {
+			p.MoveTo(pt)
+		} else {
+			p.LineTo(pt)
+		}
+	}
+	p.LineTo(first)
+	// leave the closing edge behind so a following color call sets the
+	// color of the next Star rather than recoloring this one
+	p.Break()
+	return p
+}
+
+func sincosf(a float32) (s, c float32) {
+	s64, c64 := math.Sincos(float64(a))
+	return float32(s64), float32(c64)
+}
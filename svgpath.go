@@ -0,0 +1,407 @@
+package splot
+
+import (
+	"math"
+	"strconv"
+)
+
+// SVGPath parses an SVG path `d` attribute and replays it as splot
+// primitives on the plane z=z, using CubicBezier/QuadraticBezier to flatten
+// curves and svgArcTo, an elliptical counterpart of Arc, for the `A`/`a`
+// command. It
+// supports the M/m, L/l, H/h, V/v, C/c, S/s, Q/q, T/t, A/a and Z/z commands,
+// both absolute and relative, including implicit repetition of the previous
+// command.
+func (p *Plot) SVGPath(d string, z float32) *Plot {
+	return p.SVGPathPlane(d, Vec3{0, 0, 1}, Vec3{0, 0, z})
+}
+
+// SVGPathPlane is the 3D variant of SVGPath: the path is drawn on the plane
+// through origin perpendicular to normal, with the path's +x/+y axes mapped
+// onto that plane's basis (see planeBasis).
+func (p *Plot) SVGPathPlane(d string, normal, origin Vec3) *Plot {
+	tangent, bitangent := planeBasis(normal)
+	toPt := func(x, y float64) Vec3 {
+		fx, fy := float32(x), float32(y)
+		return Vec3{
+			origin[0] + fx*tangent[0] + fy*bitangent[0],
+			origin[1] + fx*tangent[1] + fy*bitangent[1],
+			origin[2] + fx*tangent[2] + fy*bitangent[2],
+		}
+	}
+
+	l := &svgPathLexer{s: d}
+	var cx, cy, startX, startY float64
+	var havePrevCubicCtrl, havePrevQuadCtrl bool
+	var prevCubicCtrlX, prevCubicCtrlY float64
+	var prevQuadCtrlX, prevQuadCtrlY float64
+	haveCmd := false
+	havePoint := false
+	var cmd byte
+
+	readNumbers := func(n int) ([]float64, bool) {
+		nums := make([]float64, n)
+		for i := 0; i < n; i++ {
+			v, ok := l.number()
+			if !ok {
+				return nil, false
+			}
+			nums[i] = v
+		}
+		return nums, true
+	}
+
+	for {
+		if c, ok := l.command(); ok {
+			cmd = c
+			haveCmd = true
+		} else if !haveCmd || !l.hasMore() {
+			break
+		} else {
+			// implicit repetition of the previous command; a repeated
+			// moveto is treated as a lineto, per the SVG spec
+			if cmd == 'M' {
+				cmd = 'L'
+			} else if cmd == 'm' {
+				cmd = 'l'
+			}
+		}
+
+		switch cmd {
+		case 'M', 'm':
+			n, ok := readNumbers(2)
+			if !ok {
+				return p
+			}
+			if cmd == 'm' && havePoint {
+				cx, cy = cx+n[0], cy+n[1]
+			} else {
+				cx, cy = n[0], n[1]
+			}
+			startX, startY = cx, cy
+			p.MoveTo(toPt(cx, cy))
+			havePoint = true
+			havePrevCubicCtrl, havePrevQuadCtrl = false, false
+
+		case 'L', 'l':
+			n, ok := readNumbers(2)
+			if !ok {
+				return p
+			}
+			if cmd == 'l' {
+				cx, cy = cx+n[0], cy+n[1]
+			} else {
+				cx, cy = n[0], n[1]
+			}
+			p.LineTo(toPt(cx, cy))
+			havePrevCubicCtrl, havePrevQuadCtrl = false, false
+
+		case 'H', 'h':
+			n, ok := readNumbers(1)
+			if !ok {
+				return p
+			}
+			if cmd == 'h' {
+				cx += n[0]
+			} else {
+				cx = n[0]
+			}
+			p.LineTo(toPt(cx, cy))
+			havePrevCubicCtrl, havePrevQuadCtrl = false, false
+
+		case 'V', 'v':
+			n, ok := readNumbers(1)
+			if !ok {
+				return p
+			}
+			if cmd == 'v' {
+				cy += n[0]
+			} else {
+				cy = n[0]
+			}
+			p.LineTo(toPt(cx, cy))
+			havePrevCubicCtrl, havePrevQuadCtrl = false, false
+
+		case 'C', 'c':
+			n, ok := readNumbers(6)
+			if !ok {
+				return p
+			}
+			x0, y0 := cx, cy
+			var c0x, c0y, c1x, c1y, ex, ey float64
+			if cmd == 'c' {
+				c0x, c0y, c1x, c1y, ex, ey = cx+n[0], cy+n[1], cx+n[2], cy+n[3], cx+n[4], cy+n[5]
+			} else {
+				c0x, c0y, c1x, c1y, ex, ey = n[0], n[1], n[2], n[3], n[4], n[5]
+			}
+			p.CubicBezier(toPt(x0, y0), toPt(c0x, c0y), toPt(c1x, c1y), toPt(ex, ey))
+			cx, cy = ex, ey
+			prevCubicCtrlX, prevCubicCtrlY = c1x, c1y
+			havePrevCubicCtrl, havePrevQuadCtrl = true, false
+
+		case 'S', 's':
+			n, ok := readNumbers(4)
+			if !ok {
+				return p
+			}
+			x0, y0 := cx, cy
+			var c1x, c1y, ex, ey float64
+			if cmd == 's' {
+				c1x, c1y, ex, ey = cx+n[0], cy+n[1], cx+n[2], cy+n[3]
+			} else {
+				c1x, c1y, ex, ey = n[0], n[1], n[2], n[3]
+			}
+			c0x, c0y := cx, cy
+			if havePrevCubicCtrl {
+				c0x, c0y = 2*cx-prevCubicCtrlX, 2*cy-prevCubicCtrlY
+			}
+			p.CubicBezier(toPt(x0, y0), toPt(c0x, c0y), toPt(c1x, c1y), toPt(ex, ey))
+			cx, cy = ex, ey
+			prevCubicCtrlX, prevCubicCtrlY = c1x, c1y
+			havePrevCubicCtrl, havePrevQuadCtrl = true, false
+
+		case 'Q', 'q':
+			n, ok := readNumbers(4)
+			if !ok {
+				return p
+			}
+			x0, y0 := cx, cy
+			var c0x, c0y, ex, ey float64
+			if cmd == 'q' {
+				c0x, c0y, ex, ey = cx+n[0], cy+n[1], cx+n[2], cy+n[3]
+			} else {
+				c0x, c0y, ex, ey = n[0], n[1], n[2], n[3]
+			}
+			p.QuadraticBezier(toPt(x0, y0), toPt(c0x, c0y), toPt(ex, ey))
+			cx, cy = ex, ey
+			prevQuadCtrlX, prevQuadCtrlY = c0x, c0y
+			havePrevQuadCtrl, havePrevCubicCtrl = true, false
+
+		case 'T', 't':
+			n, ok := readNumbers(2)
+			if !ok {
+				return p
+			}
+			x0, y0 := cx, cy
+			var ex, ey float64
+			if cmd == 't' {
+				ex, ey = cx+n[0], cy+n[1]
+			} else {
+				ex, ey = n[0], n[1]
+			}
+			c0x, c0y := cx, cy
+			if havePrevQuadCtrl {
+				c0x, c0y = 2*cx-prevQuadCtrlX, 2*cy-prevQuadCtrlY
+			}
+			p.QuadraticBezier(toPt(x0, y0), toPt(c0x, c0y), toPt(ex, ey))
+			cx, cy = ex, ey
+			prevQuadCtrlX, prevQuadCtrlY = c0x, c0y
+			havePrevQuadCtrl, havePrevCubicCtrl = true, false
+
+		case 'A', 'a':
+			rx, ok1 := l.number()
+			ry, ok2 := l.number()
+			rotDeg, ok3 := l.number()
+			large, ok4 := l.flag()
+			sweep, ok5 := l.flag()
+			ex0, ok6 := l.number()
+			ey0, ok7 := l.number()
+			if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6 && ok7) {
+				return p
+			}
+			var ex, ey float64
+			if cmd == 'a' {
+				ex, ey = cx+ex0, cy+ey0
+			} else {
+				ex, ey = ex0, ey0
+			}
+			p.svgArcTo(cx, cy, rx, ry, rotDeg, large, sweep, ex, ey, toPt)
+			cx, cy = ex, ey
+			havePrevCubicCtrl, havePrevQuadCtrl = false, false
+
+		case 'Z', 'z':
+			p.LineTo(toPt(startX, startY))
+			cx, cy = startX, startY
+			havePrevCubicCtrl, havePrevQuadCtrl = false, false
+
+		default:
+			return p
+		}
+	}
+	return p
+}
+
+// svgArcTo converts an SVG elliptical-arc segment (endpoint parameterization)
+// to center parameterization per the W3C SVG spec, appendix F.6, then
+// tessellates it at roughly the same angular resolution as Arc.
+func (p *Plot) svgArcTo(x0, y0, rx, ry, rotDeg float64, largeArc, sweep bool, x1, y1 float64, toPt func(x, y float64) Vec3) {
+	if rx == 0 || ry == 0 {
+		p.LineTo(toPt(x1, y1))
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	phi := rotDeg * math.Pi / 180
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+
+	dx2, dy2 := (x0-x1)/2, (y0-y1)/2
+	x1p := cosPhi*dx2 + sinPhi*dy2
+	y1p := -sinPhi*dx2 + cosPhi*dy2
+
+	if lambda := x1p*x1p/(rx*rx) + y1p*y1p/(ry*ry); lambda > 1 {
+		s := math.Sqrt(lambda)
+		rx, ry = rx*s, ry*s
+	}
+
+	sign := 1.0
+	if largeArc == sweep {
+		sign = -1.0
+	}
+	num := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	den := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	co := 0.0
+	if den != 0 && num > 0 {
+		co = sign * math.Sqrt(num/den)
+	}
+	cxp := co * rx * y1p / ry
+	cyp := -co * ry * x1p / rx
+
+	cx := cosPhi*cxp - sinPhi*cyp + (x0+x1)/2
+	cy := sinPhi*cxp + cosPhi*cyp + (y0+y1)/2
+
+	angleBetween := func(ux, uy, vx, vy float64) float64 {
+		d := ux*vx + uy*vy
+		lu, lv := math.Sqrt(ux*ux+uy*uy), math.Sqrt(vx*vx+vy*vy)
+		a := math.Acos(clamp(d/(lu*lv), -1, 1))
+		if ux*vy-uy*vx < 0 {
+			a = -a
+		}
+		return a
+	}
+	theta1 := angleBetween(1, 0, (x1p-cxp)/rx, (y1p-cyp)/ry)
+	dtheta := angleBetween((x1p-cxp)/rx, (y1p-cyp)/ry, (-x1p-cxp)/rx, (-y1p-cyp)/ry)
+	if !sweep && dtheta > 0 {
+		dtheta -= 2 * math.Pi
+	} else if sweep && dtheta < 0 {
+		dtheta += 2 * math.Pi
+	}
+
+	const numSegmentsFullCircle = 60
+	n := int(math.Abs(dtheta) / (2 * math.Pi / numSegmentsFullCircle))
+	if n < 1 {
+		n = 1
+	}
+	for i := 1; i <= n; i++ {
+		t := theta1 + dtheta*float64(i)/float64(n)
+		sinT, cosT := math.Sin(t), math.Cos(t)
+		ex := cosPhi*rx*cosT - sinPhi*ry*sinT + cx
+		ey := sinPhi*rx*cosT + cosPhi*ry*sinT + cy
+		p.LineTo(toPt(ex, ey))
+	}
+}
+
+func clamp(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// svgPathLexer tokenizes an SVG path `d` attribute: command letters and
+// comma/whitespace-separated numbers (including flags, which may be packed
+// without separators, e.g. "11").
+type svgPathLexer struct {
+	s   string
+	pos int
+}
+
+func (l *svgPathLexer) skipSep() {
+	for l.pos < len(l.s) {
+		switch l.s[l.pos] {
+		case ' ', '\t', '\n', '\r', ',':
+			l.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (l *svgPathLexer) hasMore() bool {
+	l.skipSep()
+	return l.pos < len(l.s)
+}
+
+func (l *svgPathLexer) command() (byte, bool) {
+	l.skipSep()
+	if l.pos >= len(l.s) {
+		return 0, false
+	}
+	c := l.s[l.pos]
+	if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' {
+		l.pos++
+		return c, true
+	}
+	return 0, false
+}
+
+func (l *svgPathLexer) number() (float64, bool) {
+	l.skipSep()
+	n := len(l.s)
+	i := l.pos
+	start := i
+	if i < n && (l.s[i] == '+' || l.s[i] == '-') {
+		i++
+	}
+	digitsBefore := 0
+	for i < n && l.s[i] >= '0' && l.s[i] <= '9' {
+		i++
+		digitsBefore++
+	}
+	digitsAfter := 0
+	if i < n && l.s[i] == '.' {
+		i++
+		for i < n && l.s[i] >= '0' && l.s[i] <= '9' {
+			i++
+			digitsAfter++
+		}
+	}
+	if digitsBefore == 0 && digitsAfter == 0 {
+		return 0, false
+	}
+	if i < n && (l.s[i] == 'e' || l.s[i] == 'E') {
+		j := i + 1
+		if j < n && (l.s[j] == '+' || l.s[j] == '-') {
+			j++
+		}
+		k := j
+		for k < n && l.s[k] >= '0' && l.s[k] <= '9' {
+			k++
+		}
+		if k > j {
+			i = k
+		}
+	}
+	v, err := strconv.ParseFloat(l.s[start:i], 64)
+	if err != nil {
+		return 0, false
+	}
+	l.pos = i
+	return v, true
+}
+
+// flag reads a single SVG arc flag ("0" or "1"); flags aren't separated from
+// adjacent numbers by a delimiter.
+func (l *svgPathLexer) flag() (bool, bool) {
+	l.skipSep()
+	if l.pos >= len(l.s) {
+		return false, false
+	}
+	c := l.s[l.pos]
+	if c == '0' || c == '1' {
+		l.pos++
+		return c == '1', true
+	}
+	return false, false
+}
@@ -0,0 +1,124 @@
+package splot
+
+import "testing"
+
+func TestSVGPathLexerNumber(t *testing.T) {
+	cases := []struct {
+		s    string
+		want float64
+		ok   bool
+	}{
+		{"10", 10, true},
+		{"-3.5", -3.5, true},
+		{"+.25", .25, true},
+		{"1e3", 1000, true},
+		{"1.5e-2", 0.015, true},
+		{"", 0, false},
+		{"abc", 0, false},
+	}
+	for _, c := range cases {
+		l := &svgPathLexer{s: c.s}
+		got, ok := l.number()
+		if ok != c.ok || (ok && got != c.want) {
+			t.Errorf("number(%q) = %v,%v, want %v,%v", c.s, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestSVGPathLexerFlag(t *testing.T) {
+	// flags are packed without separators, e.g. "11" is two flags "1","1"
+	l := &svgPathLexer{s: "11"}
+	f0, ok0 := l.flag()
+	f1, ok1 := l.flag()
+	if !ok0 || !ok1 || !f0 || !f1 {
+		t.Fatalf("flag(),flag() on %q = %v,%v,%v,%v, want true,true,true,true", "11", f0, ok0, f1, ok1)
+	}
+	l2 := &svgPathLexer{s: "01"}
+	f2, ok2 := l2.flag()
+	f3, ok3 := l2.flag()
+	if !ok2 || !ok3 || f2 || !f3 {
+		t.Fatalf("flag(),flag() on %q = %v,%v,%v,%v, want false,true,true,true", "01", f2, ok2, f3, ok3)
+	}
+}
+
+func TestSVGPathLexerCommand(t *testing.T) {
+	l := &svgPathLexer{s: " \t M10,20"}
+	c, ok := l.command()
+	if !ok || c != 'M' {
+		t.Fatalf("command() = %q,%v, want 'M',true", c, ok)
+	}
+	if !l.hasMore() {
+		t.Fatalf("hasMore() = false after command, want true")
+	}
+}
+
+// TestSVGPathCommands covers one absolute and one relative case for each
+// SVG path command letter that takes coordinates, plus Z, checking the
+// cursor ends at the expected point.
+func TestSVGPathCommands(t *testing.T) {
+	cases := []struct {
+		name string
+		d    string
+		want Vec3
+	}{
+		{"M absolute", "M10,20", Vec3{10, 20, 0}},
+		{"m relative", "M10,20 m5,5", Vec3{15, 25, 0}},
+		{"L absolute", "M0,0 L10,20", Vec3{10, 20, 0}},
+		{"l relative", "M10,10 l5,5", Vec3{15, 15, 0}},
+		{"H absolute", "M0,0 H10", Vec3{10, 0, 0}},
+		{"h relative", "M5,0 h10", Vec3{15, 0, 0}},
+		{"V absolute", "M0,0 V10", Vec3{0, 10, 0}},
+		{"v relative", "M0,5 v10", Vec3{0, 15, 0}},
+		{"C absolute", "M0,0 C0,0 10,20 10,20", Vec3{10, 20, 0}},
+		{"c relative", "M0,0 c0,0 10,20 10,20", Vec3{10, 20, 0}},
+		{"S absolute", "M0,0 C0,0 5,5 5,5 S10,10 10,10", Vec3{10, 10, 0}},
+		{"s relative", "M0,0 c0,0 5,5 5,5 s5,5 5,5", Vec3{10, 10, 0}},
+		{"Q absolute", "M0,0 Q5,5 10,10", Vec3{10, 10, 0}},
+		{"q relative", "M0,0 q5,5 10,10", Vec3{10, 10, 0}},
+		{"T absolute", "M0,0 Q5,5 5,5 T10,10", Vec3{10, 10, 0}},
+		{"t relative", "M0,0 q5,5 5,5 t5,5", Vec3{10, 10, 0}},
+		{"A absolute", "M0,0 A5,5 0 0 1 10,0", Vec3{10, 0, 0}},
+		{"a relative", "M0,0 a5,5 0 0 1 10,0", Vec3{10, 0, 0}},
+		{"Z close", "M5,5 L10,10 Z", Vec3{5, 5, 0}},
+	}
+	const eps = 1e-4
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := new(Plot)
+			p.SVGPath(c.d, 0)
+			got := p.CurPos()
+			if abs32(got[0]-c.want[0]) > eps || abs32(got[1]-c.want[1]) > eps || abs32(got[2]-c.want[2]) > eps {
+				t.Errorf("SVGPath(%q): CurPos() = %v, want %v", c.d, got, c.want)
+			}
+		})
+	}
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// TestSVGPathImplicitRepetition checks that omitted command letters repeat
+// the previous command, and that a repeated M is treated as L per spec.
+func TestSVGPathImplicitRepetition(t *testing.T) {
+	p := new(Plot)
+	p.SVGPath("M0,0 L10,0 10,10 0,10", 0)
+	if got, want := len(p.prims), 3; got != want {
+		t.Fatalf("implicit L repetition: len(prims) = %d, want %d", got, want)
+	}
+	if got, want := p.CurPos(), (Vec3{0, 10, 0}); got != want {
+		t.Errorf("implicit L repetition: CurPos() = %v, want %v", got, want)
+	}
+
+	p2 := new(Plot)
+	p2.SVGPath("M0,0 10,10", 0)
+	if got, want := len(p2.prims), 1; got != want {
+		t.Fatalf("implicit M->L repetition: len(prims) = %d, want %d", got, want)
+	}
+	if got, want := p2.CurPos(), (Vec3{10, 10, 0}); got != want {
+		t.Errorf("implicit M->L repetition: CurPos() = %v, want %v", got, want)
+	}
+}